@@ -0,0 +1,220 @@
+// Package encoding provides auto-detection and transcoding between the
+// character encodings found in Star Citizen localization files: BIG5,
+// GBK/GB2312, UTF-8 (with or without a byte-order mark) and UTF-16.
+//
+// It exists so that translate_citizen's tools (the translator in
+// process, and the scanner in findSC) share one detection/transcoding
+// implementation instead of each reimplementing their own heuristics.
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Encoding identifies one of the source/target character encodings this
+// package knows how to handle.
+type Encoding int
+
+const (
+	// UTF8 is UTF-8 without a byte-order mark.
+	UTF8 Encoding = iota
+	// UTF8BOM is UTF-8 with a leading byte-order mark.
+	UTF8BOM
+	// BIG5 is Traditional Chinese BIG5.
+	BIG5
+	// GBK is Simplified Chinese GBK/GB2312.
+	GBK
+	// UTF16LE is UTF-16 little-endian.
+	UTF16LE
+	// UTF16BE is UTF-16 big-endian.
+	UTF16BE
+)
+
+var names = map[Encoding]string{
+	UTF8:    "UTF-8",
+	UTF8BOM: "UTF-8 (BOM)",
+	BIG5:    "BIG5",
+	GBK:     "GB2312/GBK",
+	UTF16LE: "UTF-16LE",
+	UTF16BE: "UTF-16BE",
+}
+
+// String returns the human-readable name of the encoding, as printed by
+// the scanner and translator tools.
+func (e Encoding) String() string {
+	if name, ok := names[e]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16leBOM = []byte{0xFF, 0xFE}
+	utf16beBOM = []byte{0xFE, 0xFF}
+)
+
+// Detect inspects the leading bytes of content and returns its best
+// guess at the source encoding. A BOM, when present, is authoritative.
+// Otherwise valid UTF-8 is trusted as UTF-8 outright — a UTF-8-encoded
+// CJK character legitimately uses bytes in the 0x81-0xFE range, the same
+// range BIG5/GBK trail bytes occupy, so byte-range alone can't tell them
+// apart. Only content that fails utf8.Valid is probed for BIG5/GBK.
+func Detect(content []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return UTF8BOM
+	case bytes.HasPrefix(content, utf16leBOM):
+		return UTF16LE
+	case bytes.HasPrefix(content, utf16beBOM):
+		return UTF16BE
+	}
+
+	if utf8.Valid(content) {
+		return UTF8
+	}
+
+	if isBig5(content) {
+		return BIG5
+	}
+	if isGBK(content) {
+		return GBK
+	}
+
+	return UTF8
+}
+
+func isBig5(content []byte) bool {
+	return canDecode(traditionalchinese.Big5, content) && hasDoubleByteRun(content, 0xA1, 0xF9, 0x40, 0xFE)
+}
+
+func isGBK(content []byte) bool {
+	return canDecode(simplifiedchinese.GBK, content) && hasDoubleByteRun(content, 0x81, 0xFE, 0x40, 0xFE)
+}
+
+func canDecode(enc encoding.Encoding, content []byte) bool {
+	decoded := make([]byte, len(content)*3)
+	n, _, err := enc.NewDecoder().Transform(decoded, content, true)
+	return err == nil && n > 0
+}
+
+func hasDoubleByteRun(content []byte, lo1, hi1, lo2, hi2 byte) bool {
+	for i := 0; i < len(content)-1; i++ {
+		if content[i] >= lo1 && content[i] <= hi1 && content[i+1] >= lo2 && content[i+1] <= hi2 {
+			return true
+		}
+	}
+	return false
+}
+
+// transformer returns the golang.org/x/text transform for converting the
+// given encoding to or from UTF-8. The BOM variants reuse the plain
+// UTF-8 codec; callers are responsible for stripping/adding the BOM
+// bytes themselves, since x/text's UTF-8 codec has no opinion on it.
+func transformer(e Encoding, toUTF8 bool) (transform.Transformer, error) {
+	var enc encoding.Encoding
+	switch e {
+	case UTF8, UTF8BOM:
+		return transform.Nop, nil
+	case BIG5:
+		enc = traditionalchinese.Big5
+	case GBK:
+		enc = simplifiedchinese.GBK
+	case UTF16LE:
+		enc = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+	case UTF16BE:
+		enc = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)
+	default:
+		return nil, fmt.Errorf("encoding: unsupported encoding %v", e)
+	}
+	if toUTF8 {
+		return enc.NewDecoder(), nil
+	}
+	return enc.NewEncoder(), nil
+}
+
+// NewDecoder returns a transform.Transformer that converts bytes in
+// encoding e into UTF-8.
+func NewDecoder(e Encoding) (transform.Transformer, error) {
+	return transformer(e, true)
+}
+
+// NewEncoder returns a transform.Transformer that converts UTF-8 bytes
+// into encoding e.
+func NewEncoder(e Encoding) (transform.Transformer, error) {
+	return transformer(e, false)
+}
+
+// Decode transcodes content (in the detected or supplied encoding) to a
+// UTF-8 string, stripping any BOM.
+func Decode(content []byte, e Encoding) (string, error) {
+	switch e {
+	case UTF8BOM:
+		content = bytes.TrimPrefix(content, utf8BOM)
+		return string(content), nil
+	case UTF16LE:
+		content = bytes.TrimPrefix(content, utf16leBOM)
+	case UTF16BE:
+		content = bytes.TrimPrefix(content, utf16beBOM)
+	}
+
+	dec, err := NewDecoder(e)
+	if err != nil {
+		return "", err
+	}
+	out, _, err := transform.Bytes(dec, content)
+	if err != nil {
+		return "", fmt.Errorf("encoding: decode as %s: %w", e, err)
+	}
+	return string(out), nil
+}
+
+// Encode transcodes a UTF-8 string into the target encoding, adding a
+// BOM when the target calls for one.
+func Encode(s string, target Encoding) ([]byte, error) {
+	if target == UTF8 {
+		return []byte(s), nil
+	}
+	if target == UTF8BOM {
+		return append(append([]byte{}, utf8BOM...), []byte(s)...), nil
+	}
+
+	enc, err := NewEncoder(target)
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := transform.Bytes(enc, []byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("encoding: encode as %s: %w", target, err)
+	}
+
+	switch target {
+	case UTF16LE:
+		out = append(append([]byte{}, utf16leBOM...), out...)
+	case UTF16BE:
+		out = append(append([]byte{}, utf16beBOM...), out...)
+	}
+	return out, nil
+}
+
+// ParseTarget parses the -out-encoding flag value into an Encoding.
+func ParseTarget(name string) (Encoding, error) {
+	switch name {
+	case "big5":
+		return BIG5, nil
+	case "utf8":
+		return UTF8, nil
+	case "utf8bom":
+		return UTF8BOM, nil
+	default:
+		return 0, fmt.Errorf("encoding: unknown target encoding %q (want big5, utf8, or utf8bom)", name)
+	}
+}
@@ -7,8 +7,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
-	"golang.org/x/text/encoding/simplifiedchinese"
-	"golang.org/x/text/encoding/traditionalchinese"
+	textenc "translate_citizen/encoding"
 )
 
 const (
@@ -33,125 +32,46 @@ func main() {
 	}
 
 	// Detect overall encoding
-	encoding := detectFileEncoding(content)
-	fmt.Printf("Overall file encoding: %s\n\n", encoding)
+	enc := textenc.Detect(content)
+	fmt.Printf("Overall file encoding: %s\n\n", enc)
 
 	// If file is entirely GBK/GB2312, it's all Simplified Chinese
-	if encoding == "GB2312/GBK" {
+	if enc == textenc.GBK {
 		fmt.Println("⚠️  WARNING: Entire file is encoded in GB2312/GBK (Simplified Chinese)!")
 		fmt.Println("This file should be re-encoded to BIG5 or UTF-8 with Traditional Chinese characters.")
 		fmt.Println()
-		scanAndPrintSimplifiedChars(content, true)
+		scanAndPrintSimplifiedChars(content, enc)
 		return
 	}
 
 	// For UTF-8 or BIG5, scan character by character
-	if encoding == "UTF-8" {
+	switch enc {
+	case textenc.UTF8, textenc.UTF8BOM:
 		fmt.Println("Scanning UTF-8 file for Simplified Chinese characters...")
-		scanUTF8ForSimplified(string(content))
-	} else if encoding == "BIG5" {
+		text, err := textenc.Decode(content, enc)
+		if err != nil {
+			fmt.Printf("Error decoding UTF-8: %v\n", err)
+			os.Exit(1)
+		}
+		scanUTF8ForSimplified(text)
+	case textenc.BIG5:
 		fmt.Println("✓ File is encoded in BIG5 (Traditional Chinese)")
 		fmt.Println("Scanning for any anomalies...")
-		scanBIG5File(content)
-	} else {
+		scanBIG5File(content, enc)
+	default:
 		fmt.Println("Unknown encoding - attempting byte-level scan...")
 		scanMixedEncodingFile(content)
 	}
 }
 
-// detectFileEncoding detects the primary encoding of the file
-func detectFileEncoding(content []byte) string {
-	// Check for UTF-8 BOM
-	if len(content) >= 3 && content[0] == 0xEF && content[1] == 0xBB && content[2] == 0xBF {
-		return "UTF-8"
-	}
-
-	// Check if valid UTF-8
-	if utf8.Valid(content) {
-		// Could be UTF-8, but check for Chinese byte ranges
-		hasGBKRange := false
-		hasBIG5Range := false
-
-		for i := 0; i < len(content); i++ {
-			b := content[i]
-			// GBK high byte range: 0x81-0xFE
-			if b >= 0x81 && b <= 0xFE {
-				hasGBKRange = true
-			}
-			// BIG5 high byte range: 0xA1-0xF9
-			if b >= 0xA1 && b <= 0xF9 {
-				hasBIG5Range = true
-			}
-		}
-
-		if !hasGBKRange && !hasBIG5Range {
-			return "UTF-8"
-		}
-	}
-
-	// Try BIG5 decode
-	if isBIG5Encoded(content) {
-		return "BIG5"
-	}
-
-	// Try GBK decode
-	if isGBKEncoded(content) {
-		return "GB2312/GBK"
-	}
-
-	return "UTF-8"
-}
-
-// isBIG5Encoded checks if content is BIG5 encoded
-func isBIG5Encoded(content []byte) bool {
-	decoder := traditionalchinese.Big5.NewDecoder()
-	decoded := make([]byte, len(content)*3)
-	n, _, err := decoder.Transform(decoded, content, true)
-	if err != nil {
-		return false
-	}
-	// Check if we have valid BIG5 byte ranges
-	for i := 0; i < len(content)-1; i++ {
-		if content[i] >= 0xA1 && content[i] <= 0xF9 {
-			if (content[i+1] >= 0x40 && content[i+1] <= 0x7E) ||
-				(content[i+1] >= 0xA1 && content[i+1] <= 0xFE) {
-				return n > 0
-			}
-		}
-	}
-	return false
-}
-
-// isGBKEncoded checks if content is GBK/GB2312 encoded
-func isGBKEncoded(content []byte) bool {
-	decoder := simplifiedchinese.GBK.NewDecoder()
-	decoded := make([]byte, len(content)*3)
-	n, _, err := decoder.Transform(decoded, content, true)
-	if err != nil {
-		return false
-	}
-	// Check if we have valid GBK byte ranges
-	for i := 0; i < len(content)-1; i++ {
-		if content[i] >= 0x81 && content[i] <= 0xFE {
-			if content[i+1] >= 0x40 && content[i+1] <= 0xFE {
-				return n > 0
-			}
-		}
-	}
-	return false
-}
-
 // scanAndPrintSimplifiedChars prints all characters from a GBK-encoded file
-func scanAndPrintSimplifiedChars(content []byte, isGBK bool) {
-	decoder := simplifiedchinese.GBK.NewDecoder()
-	decoded := make([]byte, len(content)*3)
-	n, _, err := decoder.Transform(decoded, content, true)
+func scanAndPrintSimplifiedChars(content []byte, enc textenc.Encoding) {
+	text, err := textenc.Decode(content, enc)
 	if err != nil {
 		fmt.Printf("Error decoding GBK: %v\n", err)
 		return
 	}
 
-	text := string(decoded[:n])
 	scanner := bufio.NewScanner(strings.NewReader(text))
 	lineNum := 0
 	foundCount := 0
@@ -250,16 +170,13 @@ func scanUTF8ForSimplified(content string) {
 }
 
 // scanBIG5File scans a BIG5 encoded file
-func scanBIG5File(content []byte) {
-	decoder := traditionalchinese.Big5.NewDecoder()
-	decoded := make([]byte, len(content)*3)
-	n, _, err := decoder.Transform(decoded, content, true)
+func scanBIG5File(content []byte, enc textenc.Encoding) {
+	text, err := textenc.Decode(content, enc)
 	if err != nil {
 		fmt.Printf("Error decoding BIG5: %v\n", err)
 		return
 	}
 
-	text := string(decoded[:n])
 	fmt.Println("✓ Successfully decoded as BIG5")
 	fmt.Printf("Total characters: %d\n", utf8.RuneCountInString(text))
 	fmt.Println("\nNo Simplified Chinese encoding detected.")
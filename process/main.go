@@ -2,12 +2,19 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"runtime"
 	"strings"
+
+	"golang.org/x/text/transform"
+
+	textenc "translate_citizen/encoding"
 )
 
 const (
@@ -19,6 +26,30 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		if err := runMerge(os.Args[2:]); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
+	runTranslate()
+}
+
+// runTranslate runs the default copy/translate pipeline: back up the
+// previous output, copy the English source over it, then translate it
+// in place.
+func runTranslate() {
+	outEncoding := flag.String("out-encoding", "utf8", "output encoding: big5, utf8, or utf8bom")
+	strict := flag.Bool("strict", false, "exit with a non-zero status if any placeholder mismatches are found")
+	jobs := flag.Int("jobs", runtime.NumCPU(), "number of parallel workers; 1 uses the single-threaded path")
+	flag.Parse()
+
+	targetEncoding, err := textenc.ParseTarget(*outEncoding)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
 	fmt.Println("=== Translation Processor ===")
 	fmt.Println()
 
@@ -43,7 +74,12 @@ func main() {
 	fmt.Printf("✓ Step 3: Loaded %d translations from %s\n", len(translationMap), translationMapTC)
 
 	// Step 4: Translate output/global.ini
-	stats, err := translateFile(outputFilePath, translationMap)
+	var stats *TranslationStats
+	if *jobs <= 1 {
+		stats, err = translateFile(outputFilePath, translationMap, targetEncoding)
+	} else {
+		stats, err = translateFileParallel(outputFilePath, translationMap, targetEncoding, *jobs)
+	}
 	if err != nil {
 		log.Fatalf("Error translating file: %v", err)
 	}
@@ -57,18 +93,26 @@ func main() {
 	fmt.Printf("Lines unchanged: %d\n", stats.Unchanged)
 	fmt.Printf("Lines skipped (empty/comment): %d\n", stats.Skipped)
 	fmt.Printf("Keys not found in map: %d\n", stats.NotFound)
+	fmt.Printf("Keys converted S->T as fallback: %d\n", stats.Converted)
+	fmt.Printf("Placeholder mismatches: %d\n", stats.PlaceholderMismatch)
 	fmt.Println()
 	fmt.Printf("Output file: %s\n", outputFilePath)
 	fmt.Printf("Backup file: %s\n", backupFilePath)
+
+	if stats.PlaceholderMismatch > 0 && *strict {
+		log.Fatalf("Error: %d placeholder mismatches found (-strict)", stats.PlaceholderMismatch)
+	}
 }
 
 // TranslationStats holds statistics about the translation process
 type TranslationStats struct {
-	TotalLines int
-	Translated int
-	Unchanged  int
-	Skipped    int
-	NotFound   int
+	TotalLines          int
+	Translated          int
+	Unchanged           int
+	Skipped             int
+	NotFound            int
+	Converted           int
+	PlaceholderMismatch int
 }
 
 // backupFile moves a file from src to dst (essentially a rename)
@@ -131,14 +175,22 @@ func loadTranslationMap(filepath string) (map[string]string, error) {
 	return translationMap, nil
 }
 
-// translateFile translates the INI file using the provided translation map
-func translateFile(filepath string, translationMap map[string]string) (*TranslationStats, error) {
-	// Read the file
-	inputFile, err := os.Open(filepath)
+// translateFile translates the INI file using the provided translation
+// map. The source encoding is auto-detected and streamed through a
+// transform.Transformer chain so large global.ini files don't need to be
+// fully decoded into memory; the result is re-encoded into targetEncoding
+// on write.
+func translateFile(filepath string, translationMap map[string]string, targetEncoding textenc.Encoding) (*TranslationStats, error) {
+	raw, err := os.ReadFile(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file for reading: %w", err)
 	}
-	defer inputFile.Close()
+
+	sourceEncoding := textenc.Detect(raw)
+	decoder, err := textenc.NewDecoder(sourceEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build decoder for %s: %w", sourceEncoding, err)
+	}
 
 	// Create temporary file for writing
 	tempFile, err := os.CreateTemp("", "translation_*.ini")
@@ -148,39 +200,29 @@ func translateFile(filepath string, translationMap map[string]string) (*Translat
 	tempPath := tempFile.Name()
 	defer os.Remove(tempPath) // Clean up temp file if something goes wrong
 
+	encoder, err := textenc.NewEncoder(targetEncoding)
+	if err != nil {
+		tempFile.Close()
+		return nil, fmt.Errorf("failed to build encoder for %s: %w", targetEncoding, err)
+	}
+
 	stats := &TranslationStats{}
-	scanner := bufio.NewScanner(inputFile)
-	writer := bufio.NewWriter(tempFile)
+	scanner := bufio.NewScanner(transform.NewReader(bytes.NewReader(raw), decoder))
+	writer := bufio.NewWriter(transform.NewWriter(tempFile, encoder))
 	lineNumber := 0
 
+	if err := writeBOMIfNeeded(writer, targetEncoding == textenc.UTF8BOM); err != nil {
+		tempFile.Close()
+		return nil, err
+	}
+
 	for scanner.Scan() {
 		lineNumber++
 		stats.TotalLines++
-		line := scanner.Text()
-		originalLine := line
-
-		// Handle UTF-8 BOM if present (at the start of file)
-		if lineNumber == 1 && strings.HasPrefix(line, "\ufeff") {
-			// Keep the BOM and process the rest
-			bomPrefix := "\ufeff"
-			line = strings.TrimPrefix(line, bomPrefix)
-
-			// Process the line without BOM
-			processedLine := processLine(line, translationMap, stats)
-
-			// Write back with BOM
-			fmt.Fprintf(writer, "%s%s\n", bomPrefix, processedLine)
-			continue
-		}
+		line := stripLeadingBOM(scanner.Text(), lineNumber)
 
-		// Process normal lines
 		processedLine := processLine(line, translationMap, stats)
 		fmt.Fprintf(writer, "%s\n", processedLine)
-
-		// Track if line was actually changed
-		if processedLine != originalLine && !isEmptyOrComment(originalLine) {
-			// Line was translated (already counted in processLine)
-		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -203,6 +245,29 @@ func translateFile(filepath string, translationMap map[string]string) (*Translat
 	return stats, nil
 }
 
+// writeBOMIfNeeded writes a UTF-8 BOM to writer when present is true. It
+// is shared by translateFile and the merge command so both write a BOM
+// under the same rule (only for the utf8bom target); a BOM on the source
+// file is not carried over.
+func writeBOMIfNeeded(writer *bufio.Writer, present bool) error {
+	if !present {
+		return nil
+	}
+	if _, err := writer.WriteString("\ufeff"); err != nil {
+		return fmt.Errorf("error writing BOM: %w", err)
+	}
+	return nil
+}
+
+// stripLeadingBOM removes a UTF-8 BOM from the first line of a file, if
+// present. Later lines are returned unchanged.
+func stripLeadingBOM(line string, lineNumber int) string {
+	if lineNumber != 1 {
+		return line
+	}
+	return strings.TrimPrefix(line, "\ufeff")
+}
+
 // processLine processes a single line and returns the translated version
 func processLine(line string, translationMap map[string]string, stats *TranslationStats) string {
 	trimmed := strings.TrimSpace(line)
@@ -223,7 +288,7 @@ func processLine(line string, translationMap map[string]string, stats *Translati
 
 	// Extract key and value
 	key := strings.TrimSpace(line[:index])
-	_ = strings.TrimSpace(line[index+1:]) // value unused in lookup, only for validation
+	value := strings.TrimSpace(line[index+1:])
 
 	if key == "" {
 		stats.Unchanged++
@@ -233,19 +298,27 @@ func processLine(line string, translationMap map[string]string, stats *Translati
 	// Look up translation
 	if translatedValue, found := translationMap[key]; found {
 		stats.Translated++
+		if !placeholdersMatch(value, translatedValue) {
+			stats.PlaceholderMismatch++
+		}
 		// Preserve the original spacing around '='
 		// Reconstruct the line with translated value
 		return key + "=" + translatedValue
 	}
 
-	// Key not found in translation map
+	// Key not found in translation map: fall back to Simplified->
+	// Traditional character conversion of the literal spans only, so
+	// runtime tokens like ~action(...) or %ls survive untouched.
 	stats.NotFound++
+	converted := convertLiteralSpans(value)
+	if converted != value {
+		stats.Converted++
+		if !placeholdersMatch(value, converted) {
+			stats.PlaceholderMismatch++
+		}
+		return key + "=" + converted
+	}
+
 	stats.Unchanged++
 	return line
 }
-
-// isEmptyOrComment checks if a line is empty or a comment
-func isEmptyOrComment(line string) bool {
-	trimmed := strings.TrimSpace(line)
-	return trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";")
-}
\ No newline at end of file
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/text/transform"
+
+	textenc "translate_citizen/encoding"
+)
+
+// fuzzyComment marks a carried-forward translation whose English source
+// value changed since it was translated, so a human should re-check it.
+const fuzzyComment = "# FUZZY"
+
+// MergeStats summarizes how the keys in the new source compare against
+// the previous source/translation, mirroring TranslationStats for the
+// merge subcommand.
+type MergeStats struct {
+	TotalKeys int
+	Added     int
+	Removed   int
+	Changed   int
+	Unchanged int
+}
+
+// MergeEntry records one key's disposition during a merge, including
+// its line number so a maintainer can jump straight to it.
+type MergeEntry struct {
+	Key  string `json:"key"`
+	Line int    `json:"line"`
+}
+
+// MergeReport is written to report.json after a merge so maintainers can
+// review exactly what changed without re-diffing the INI files.
+type MergeReport struct {
+	Stats     MergeStats   `json:"stats"`
+	Added     []MergeEntry `json:"added"`
+	Removed   []MergeEntry `json:"removed"`
+	Changed   []MergeEntry `json:"changed"`
+	Unchanged []MergeEntry `json:"unchanged"`
+}
+
+// iniEntry is one parsed "key=value" pair and the line it came from.
+// Fuzzy records whether the entry's key=value line was preceded by a
+// "# FUZZY" comment, with only blank or comment lines in between, so
+// merge can re-emit the marker on
+// subsequent runs until a human removes it.
+type iniEntry struct {
+	Value string
+	Line  int
+	Fuzzy bool
+}
+
+// runMerge implements the `merge` subcommand: carry forward translations
+// for keys whose English value is unchanged between prevSource and
+// newSource, flag changed ones as fuzzy, and report added/removed keys.
+// Output is written in outEncoding, the same as translateFile: a UTF-8
+// BOM is written only when outEncoding is utf8bom. A BOM on the input
+// files is stripped on read and is not carried over otherwise.
+func runMerge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	newSourcePath := fs.String("new-source", "../source/global.ini", "new English source/global.ini")
+	prevSourcePath := fs.String("prev-source", "", "archived previous English source/global.ini")
+	prevOutputPath := fs.String("prev-output", "", "previous translated output/global.ini")
+	outPath := fs.String("out", "output/global.ini", "path to write the merged translation to")
+	reportPath := fs.String("report", "report.json", "path to write the merge report to")
+	outEncoding := fs.String("out-encoding", "utf8", "output encoding: big5, utf8, or utf8bom")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *prevSourcePath == "" || *prevOutputPath == "" {
+		return fmt.Errorf("merge requires -prev-source and -prev-output")
+	}
+
+	targetEncoding, err := textenc.ParseTarget(*outEncoding)
+	if err != nil {
+		return err
+	}
+
+	newLines, err := readLines(*newSourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read new source: %w", err)
+	}
+
+	prevSource, err := parseINIEntries(*prevSourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read previous source: %w", err)
+	}
+
+	prevOutput, err := parseINIEntries(*prevOutputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read previous output: %w", err)
+	}
+
+	report := &MergeReport{}
+	seen := make(map[string]bool, len(prevSource))
+
+	outFile, err := os.Create(*outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create merged output: %w", err)
+	}
+	defer outFile.Close()
+
+	encoder, err := textenc.NewEncoder(targetEncoding)
+	if err != nil {
+		return fmt.Errorf("failed to build encoder for %s: %w", targetEncoding, err)
+	}
+	writer := bufio.NewWriter(transform.NewWriter(outFile, encoder))
+
+	if err := writeBOMIfNeeded(writer, targetEncoding == textenc.UTF8BOM); err != nil {
+		return err
+	}
+
+	for lineNumber, line := range newLines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			fmt.Fprintf(writer, "%s\n", line)
+			continue
+		}
+
+		index := strings.Index(line, "=")
+		if index == -1 {
+			fmt.Fprintf(writer, "%s\n", line)
+			continue
+		}
+
+		key := strings.TrimSpace(line[:index])
+		newValue := strings.TrimSpace(line[index+1:])
+		if key == "" {
+			fmt.Fprintf(writer, "%s\n", line)
+			continue
+		}
+
+		report.Stats.TotalKeys++
+		seen[key] = true
+		entry := MergeEntry{Key: key, Line: lineNumber + 1}
+
+		prev, existed := prevSource[key]
+		switch {
+		case !existed:
+			report.Stats.Added++
+			report.Added = append(report.Added, entry)
+			fmt.Fprintf(writer, "%s=%s\n", key, newValue)
+
+		case prev.Value == newValue:
+			report.Stats.Unchanged++
+			report.Unchanged = append(report.Unchanged, entry)
+			translated, ok := prevOutput[key]
+			switch {
+			case !ok:
+				fmt.Fprintf(writer, "%s=%s\n", key, newValue)
+			case translated.Fuzzy:
+				// Still unresolved from an earlier merge; keep flagging
+				// it until a human edits the translation.
+				fmt.Fprintf(writer, "%s\n%s=%s\n", fuzzyComment, key, translated.Value)
+			default:
+				fmt.Fprintf(writer, "%s=%s\n", key, translated.Value)
+			}
+
+		default:
+			report.Stats.Changed++
+			report.Changed = append(report.Changed, entry)
+			translated, ok := prevOutput[key]
+			if !ok {
+				translated.Value = newValue
+			}
+			fmt.Fprintf(writer, "%s\n%s=%s\n", fuzzyComment, key, translated.Value)
+		}
+	}
+
+	for key, prev := range prevSource {
+		if seen[key] {
+			continue
+		}
+		report.Stats.Removed++
+		report.Removed = append(report.Removed, MergeEntry{Key: key, Line: prev.Line})
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush merged output: %w", err)
+	}
+
+	if err := writeMergeReport(*reportPath, report); err != nil {
+		return fmt.Errorf("failed to write merge report: %w", err)
+	}
+
+	fmt.Println("=== Merge Complete ===")
+	fmt.Printf("Total keys: %d\n", report.Stats.TotalKeys)
+	fmt.Printf("Added: %d\n", report.Stats.Added)
+	fmt.Printf("Removed: %d\n", report.Stats.Removed)
+	fmt.Printf("Changed (marked FUZZY): %d\n", report.Stats.Changed)
+	fmt.Printf("Unchanged (carried forward): %d\n", report.Stats.Unchanged)
+	fmt.Printf("Merged output: %s\n", *outPath)
+	fmt.Printf("Report: %s\n", *reportPath)
+
+	return nil
+}
+
+// readLines reads every line of path, preserving order. The file's
+// encoding is auto-detected and transcoded to UTF-8 (stripping any BOM)
+// via the shared encoding package, so merge handles BIG5/GBK/UTF-16
+// archives the same way the translator and scanner do.
+func readLines(path string) ([]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	enc := textenc.Detect(raw)
+	text, err := textenc.Decode(raw, enc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file as %s: %w", enc, err)
+	}
+
+	lines := strings.Split(text, "\n")
+	if strings.HasSuffix(text, "\n") {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// parseINIEntries reads path and returns its key/value pairs along with
+// the line number each key was found on.
+func parseINIEntries(path string) (map[string]iniEntry, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]iniEntry)
+	pendingFuzzy := false
+	for i, line := range lines {
+		lineNumber := i + 1
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == fuzzyComment {
+			pendingFuzzy = true
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+
+		index := strings.Index(line, "=")
+		if index == -1 {
+			pendingFuzzy = false
+			continue
+		}
+
+		key := strings.TrimSpace(line[:index])
+		value := strings.TrimSpace(line[index+1:])
+		if key == "" {
+			pendingFuzzy = false
+			continue
+		}
+
+		entries[key] = iniEntry{Value: value, Line: lineNumber, Fuzzy: pendingFuzzy}
+		pendingFuzzy = false
+	}
+	return entries, nil
+}
+
+// writeMergeReport writes report as indented JSON to path.
+func writeMergeReport(path string, report *MergeReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestRunMergeCarriesForwardUnchangedKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	prevSource := writeTestFile(t, dir, "prev_source.ini", "Hello=Hello\nBye=Bye\n")
+	prevOutput := writeTestFile(t, dir, "prev_output.ini", "Hello=你好\nBye=再見\n")
+	newSource := writeTestFile(t, dir, "new_source.ini", "Hello=Hello\nBye=Goodbye\nWelcome=Welcome\n")
+	outPath := filepath.Join(dir, "output.ini")
+	reportPath := filepath.Join(dir, "report.json")
+
+	err := runMerge([]string{
+		"-new-source", newSource,
+		"-prev-source", prevSource,
+		"-prev-output", prevOutput,
+		"-out", outPath,
+		"-report", reportPath,
+	})
+	if err != nil {
+		t.Fatalf("runMerge failed: %v", err)
+	}
+
+	out, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read merged output: %v", err)
+	}
+
+	got := string(out)
+	if !containsLine(got, "Hello=你好") {
+		t.Errorf("expected unchanged key Hello to carry forward its translation, got:\n%s", got)
+	}
+	if !containsLine(got, fuzzyComment) {
+		t.Errorf("expected a %s marker for the changed Bye key, got:\n%s", fuzzyComment, got)
+	}
+	if !containsLine(got, "Bye=再見") {
+		t.Errorf("expected changed key Bye to carry forward its stale translation under FUZZY, got:\n%s", got)
+	}
+	if !containsLine(got, "Welcome=Welcome") {
+		t.Errorf("expected added key Welcome to pass through untranslated, got:\n%s", got)
+	}
+}
+
+func TestRunMergePreservesFuzzyAcrossSubsequentRuns(t *testing.T) {
+	dir := t.TempDir()
+
+	// Run 1: Bye's English value changes, so it's carried forward
+	// stale and marked FUZZY.
+	prevSourceV1 := writeTestFile(t, dir, "prev_source_v1.ini", "Bye=Bye\n")
+	prevOutputV1 := writeTestFile(t, dir, "prev_output_v1.ini", "Bye=再見\n")
+	sourceV2 := writeTestFile(t, dir, "source_v2.ini", "Bye=Goodbye\n")
+	outputV2 := filepath.Join(dir, "output_v2.ini")
+	reportV2 := filepath.Join(dir, "report_v2.json")
+
+	if err := runMerge([]string{
+		"-new-source", sourceV2,
+		"-prev-source", prevSourceV1,
+		"-prev-output", prevOutputV1,
+		"-out", outputV2,
+		"-report", reportV2,
+	}); err != nil {
+		t.Fatalf("first runMerge failed: %v", err)
+	}
+
+	// Run 2: the English source is unchanged since run 1 (still
+	// "Goodbye"), and the translation is still unresolved. The FUZZY
+	// marker must survive even though the source value now matches.
+	sourceV3 := writeTestFile(t, dir, "source_v3.ini", "Bye=Goodbye\n")
+	outputV3 := filepath.Join(dir, "output_v3.ini")
+	reportV3 := filepath.Join(dir, "report_v3.json")
+
+	if err := runMerge([]string{
+		"-new-source", sourceV3,
+		"-prev-source", sourceV2,
+		"-prev-output", outputV2,
+		"-out", outputV3,
+		"-report", reportV3,
+	}); err != nil {
+		t.Fatalf("second runMerge failed: %v", err)
+	}
+
+	out, err := os.ReadFile(outputV3)
+	if err != nil {
+		t.Fatalf("failed to read second merge output: %v", err)
+	}
+
+	got := string(out)
+	if !containsLine(got, fuzzyComment) {
+		t.Errorf("expected the FUZZY marker to survive a run where the source value no longer changes, got:\n%s", got)
+	}
+	if !containsLine(got, "Bye=再見") {
+		t.Errorf("expected the stale translation to still be carried forward, got:\n%s", got)
+	}
+}
+
+func TestRunMergePreservesBOMOnOutput(t *testing.T) {
+	dir := t.TempDir()
+
+	prevSource := writeTestFile(t, dir, "prev_source.ini", "Hello=Hello\n")
+	prevOutput := writeTestFile(t, dir, "prev_output.ini", "Hello=你好\n")
+	newSource := writeTestFile(t, dir, "new_source.ini", "Hello=Hello\n")
+	outPath := filepath.Join(dir, "output.ini")
+	reportPath := filepath.Join(dir, "report.json")
+
+	err := runMerge([]string{
+		"-new-source", newSource,
+		"-prev-source", prevSource,
+		"-prev-output", prevOutput,
+		"-out", outPath,
+		"-report", reportPath,
+		"-out-encoding", "utf8bom",
+	})
+	if err != nil {
+		t.Fatalf("runMerge failed: %v", err)
+	}
+
+	out, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read merged output: %v", err)
+	}
+
+	if !strings.HasPrefix(string(out), "\ufeff") {
+		t.Errorf("expected merged output to start with a UTF-8 BOM, got:\n%s", out)
+	}
+}
+
+func containsLine(content, line string) bool {
+	for _, l := range strings.Split(content, "\n") {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/exp/mmap"
+	"golang.org/x/text/transform"
+
+	textenc "translate_citizen/encoding"
+)
+
+// lineJob is one line of input handed to a worker, tagged with its
+// original position so output can be reassembled in order.
+type lineJob struct {
+	seq  int
+	line string
+}
+
+// lineResult is a worker's translated output for one lineJob.
+type lineResult struct {
+	seq  int
+	text string
+}
+
+// translateFileParallel re-implements translateFile as a pipeline: the
+// source file is mmap'd, and its bytes are streamed line-by-line through
+// a transform.Transformer chain (the same pattern translateFile uses)
+// rather than being decoded into one big string up front. A pool of jobs
+// workers performs the per-line translation lookups concurrently, and an
+// ordered collector writes each line's result to the output as soon as
+// it's available, reassembling the original line order using each job's
+// sequence number without ever buffering the whole file's output at
+// once. Per-worker TranslationStats are merged at the end to avoid
+// contention on a shared counter.
+func translateFileParallel(filepath string, translationMap map[string]string, targetEncoding textenc.Encoding, jobs int) (*TranslationStats, error) {
+	reader, err := mmap.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mmap file for reading: %w", err)
+	}
+	defer reader.Close()
+
+	raw := make([]byte, reader.Len())
+	if _, err := reader.ReadAt(raw, 0); err != nil {
+		return nil, fmt.Errorf("failed to read mmap'd file: %w", err)
+	}
+
+	sourceEncoding := textenc.Detect(raw)
+	decoder, err := textenc.NewDecoder(sourceEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build decoder for %s: %w", sourceEncoding, err)
+	}
+
+	tempFile, err := os.CreateTemp("", "translation_*.ini")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	encoder, err := textenc.NewEncoder(targetEncoding)
+	if err != nil {
+		tempFile.Close()
+		return nil, fmt.Errorf("failed to build encoder for %s: %w", targetEncoding, err)
+	}
+
+	writer := bufio.NewWriter(transform.NewWriter(tempFile, encoder))
+	if err := writeBOMIfNeeded(writer, targetEncoding == textenc.UTF8BOM); err != nil {
+		tempFile.Close()
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(transform.NewReader(bytes.NewReader(raw), decoder))
+	stats, err := runWorkerPool(scanner, writer, translationMap, jobs)
+	if err != nil {
+		tempFile.Close()
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	if err := writer.Flush(); err != nil {
+		tempFile.Close()
+		return nil, fmt.Errorf("error flushing writer: %w", err)
+	}
+	tempFile.Close()
+
+	if err := os.Rename(tempPath, filepath); err != nil {
+		return nil, fmt.Errorf("failed to replace original file: %w", err)
+	}
+
+	return stats, nil
+}
+
+// runWorkerPool fans lines scanned from src out across jobs worker
+// goroutines, each doing read-only translationMap lookups, and writes
+// each result to dst in original line order as soon as it becomes
+// available. Results that arrive out of order are held in a small
+// pending map until the lines ahead of them land, so dst never needs the
+// whole file's output buffered at once. It returns the merged
+// TranslationStats across all workers.
+func runWorkerPool(src *bufio.Scanner, dst *bufio.Writer, translationMap map[string]string, jobs int) (*TranslationStats, error) {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	jobsCh := make(chan lineJob)
+	resultsCh := make(chan lineResult)
+	workerStats := make([]TranslationStats, jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(jobs)
+	for w := 0; w < jobs; w++ {
+		go func(worker int) {
+			defer wg.Done()
+			local := &workerStats[worker]
+			for job := range jobsCh {
+				line := job.line
+				if job.seq == 0 {
+					line = stripLeadingBOM(line, 1)
+				}
+				local.TotalLines++
+				resultsCh <- lineResult{seq: job.seq, text: processLine(line, translationMap, local)}
+			}
+		}(w)
+	}
+
+	var scanErr error
+	go func() {
+		seq := 0
+		for src.Scan() {
+			jobsCh <- lineJob{seq: seq, line: src.Text()}
+			seq++
+		}
+		scanErr = src.Err()
+		close(jobsCh)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	pending := make(map[int]string)
+	next := 0
+	for res := range resultsCh {
+		pending[res.seq] = res.text
+		for {
+			text, ok := pending[next]
+			if !ok {
+				break
+			}
+			fmt.Fprintf(dst, "%s\n", text)
+			delete(pending, next)
+			next++
+		}
+	}
+
+	if scanErr != nil {
+		return nil, scanErr
+	}
+
+	return mergeWorkerStats(workerStats), nil
+}
+
+// mergeWorkerStats sums per-worker TranslationStats into a single
+// TranslationStats equivalent to what the single-threaded translateFile
+// would have produced.
+func mergeWorkerStats(workerStats []TranslationStats) *TranslationStats {
+	stats := &TranslationStats{}
+	for _, s := range workerStats {
+		stats.TotalLines += s.TotalLines
+		stats.Translated += s.Translated
+		stats.Unchanged += s.Unchanged
+		stats.Skipped += s.Skipped
+		stats.NotFound += s.NotFound
+		stats.Converted += s.Converted
+		stats.PlaceholderMismatch += s.PlaceholderMismatch
+	}
+	return stats
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	textenc "translate_citizen/encoding"
+)
+
+// syntheticINISize is the approximate size, in bytes, of the INI file
+// generated for the single-vs-parallel translateFile benchmarks below.
+const syntheticINISize = 100 * 1024 * 1024
+
+func generateSyntheticINI(targetBytes int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < targetBytes; i++ {
+		fmt.Fprintf(&b, "SyntheticKey_%d,P=Some benchmark English text for line %d\n", i, i)
+	}
+	return b.String()
+}
+
+func syntheticTranslationMap(content string) map[string]string {
+	m := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		index := strings.Index(line, "=")
+		if index == -1 {
+			continue
+		}
+		key := line[:index]
+		m[key] = "合成翻譯文字"
+	}
+	return m
+}
+
+func writeBenchFile(b *testing.B, dir, content string) string {
+	b.Helper()
+	path := filepath.Join(dir, "bench_global.ini")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		b.Fatalf("failed to write benchmark file: %v", err)
+	}
+	return path
+}
+
+func BenchmarkTranslateFileSingleThreaded(b *testing.B) {
+	content := generateSyntheticINI(syntheticINISize)
+	translationMap := syntheticTranslationMap(content)
+	dir := b.TempDir()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		path := writeBenchFile(b, dir, content)
+		b.StartTimer()
+
+		if _, err := translateFile(path, translationMap, textenc.UTF8); err != nil {
+			b.Fatalf("translateFile failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkTranslateFileParallel(b *testing.B) {
+	content := generateSyntheticINI(syntheticINISize)
+	translationMap := syntheticTranslationMap(content)
+	dir := b.TempDir()
+	jobs := runtime.NumCPU()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		path := writeBenchFile(b, dir, content)
+		b.StartTimer()
+
+		if _, err := translateFileParallel(path, translationMap, textenc.UTF8, jobs); err != nil {
+			b.Fatalf("translateFileParallel failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	textenc "translate_citizen/encoding"
+)
+
+func TestTranslateFileParallelMatchesSingleThreaded(t *testing.T) {
+	content := "Hello,P=Hello\nBye,P=Goodbye\n# a comment\n\nUnmatched,P=no translation here\n"
+	translationMap := map[string]string{
+		"Hello,P": "你好",
+		"Bye,P":   "再見",
+	}
+
+	dir := t.TempDir()
+	singlePath := filepath.Join(dir, "single.ini")
+	parallelPath := filepath.Join(dir, "parallel.ini")
+	if err := os.WriteFile(singlePath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write single-threaded input: %v", err)
+	}
+	if err := os.WriteFile(parallelPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write parallel input: %v", err)
+	}
+
+	singleStats, err := translateFile(singlePath, translationMap, textenc.UTF8)
+	if err != nil {
+		t.Fatalf("translateFile failed: %v", err)
+	}
+
+	parallelStats, err := translateFileParallel(parallelPath, translationMap, textenc.UTF8, 4)
+	if err != nil {
+		t.Fatalf("translateFileParallel failed: %v", err)
+	}
+
+	singleOut, err := os.ReadFile(singlePath)
+	if err != nil {
+		t.Fatalf("failed to read single-threaded output: %v", err)
+	}
+	parallelOut, err := os.ReadFile(parallelPath)
+	if err != nil {
+		t.Fatalf("failed to read parallel output: %v", err)
+	}
+
+	if string(singleOut) != string(parallelOut) {
+		t.Errorf("parallel output diverged from single-threaded output:\nsingle:   %q\nparallel: %q", singleOut, parallelOut)
+	}
+	if *singleStats != *parallelStats {
+		t.Errorf("parallel stats diverged from single-threaded stats: single=%+v parallel=%+v", singleStats, parallelStats)
+	}
+}
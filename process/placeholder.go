@@ -0,0 +1,94 @@
+package main
+
+import (
+	"regexp"
+
+	"translate_citizen/s2t"
+)
+
+// placeholderPattern matches the runtime format tokens Star Citizen
+// embeds in global.ini values: function-style tokens like
+// ~mission(Name) and ~action(...), printf-style tokens like %ls, the
+// literal two-character escape \n, and XML-ish tags.
+var placeholderPattern = regexp.MustCompile(`~\w+\([^)]*\)|%[a-zA-Z]+|\\n|<[^<>]+>`)
+
+// span is one literal or placeholder chunk of a tokenized value.
+type span struct {
+	text        string
+	placeholder bool
+}
+
+// tokenize splits value into a sequence of literal and placeholder
+// spans, in order, so callers can transform literal text without
+// touching placeholder tokens.
+func tokenize(value string) []span {
+	matches := placeholderPattern.FindAllStringIndex(value, -1)
+	if len(matches) == 0 {
+		return []span{{text: value}}
+	}
+
+	spans := make([]span, 0, len(matches)*2+1)
+	last := 0
+	for _, m := range matches {
+		if m[0] > last {
+			spans = append(spans, span{text: value[last:m[0]]})
+		}
+		spans = append(spans, span{text: value[m[0]:m[1]], placeholder: true})
+		last = m[1]
+	}
+	if last < len(value) {
+		spans = append(spans, span{text: value[last:]})
+	}
+	return spans
+}
+
+// placeholders returns every placeholder token found in value, in
+// order, including duplicates.
+func placeholders(value string) []string {
+	return placeholderPattern.FindAllString(value, -1)
+}
+
+// placeholdersMatch reports whether source and translated contain the
+// same multiset of placeholder tokens, regardless of order. A mismatch
+// means the translated value dropped, duplicated, or corrupted a
+// runtime token such as ~action(...) or %ls.
+func placeholdersMatch(source, translated string) bool {
+	want := placeholders(source)
+	got := placeholders(translated)
+	if len(want) != len(got) {
+		return false
+	}
+
+	counts := make(map[string]int, len(want))
+	for _, p := range want {
+		counts[p]++
+	}
+	for _, p := range got {
+		counts[p]--
+	}
+	for _, c := range counts {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// convertLiteralSpans runs s2t.Convert over only the literal spans of
+// value, leaving placeholder tokens untouched.
+func convertLiteralSpans(value string) string {
+	spans := tokenize(value)
+	if len(spans) == 1 && !spans[0].placeholder {
+		return s2t.Convert(spans[0].text)
+	}
+
+	var b []byte
+	for _, sp := range spans {
+		if sp.placeholder {
+			b = append(b, sp.text...)
+		} else {
+			b = append(b, s2t.Convert(sp.text)...)
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestTokenizePreservesPlaceholders(t *testing.T) {
+	value := `~mission(Name) 你好 %ls\n<b>结束</b>`
+	spans := tokenize(value)
+
+	var rebuilt string
+	for _, sp := range spans {
+		rebuilt += sp.text
+	}
+	if rebuilt != value {
+		t.Fatalf("tokenize() spans don't reassemble to the original value: got %q, want %q", rebuilt, value)
+	}
+
+	var gotPlaceholders []string
+	for _, sp := range spans {
+		if sp.placeholder {
+			gotPlaceholders = append(gotPlaceholders, sp.text)
+		}
+	}
+	want := []string{"~mission(Name)", "%ls", `\n`, "<b>", "</b>"}
+	if len(gotPlaceholders) != len(want) {
+		t.Fatalf("placeholder count = %d, want %d (%v)", len(gotPlaceholders), len(want), gotPlaceholders)
+	}
+	for i, w := range want {
+		if gotPlaceholders[i] != w {
+			t.Errorf("placeholder[%d] = %q, want %q", i, gotPlaceholders[i], w)
+		}
+	}
+}
+
+func TestPlaceholdersMatch(t *testing.T) {
+	source := `~mission(Name) hello %ls\n`
+	translated := `~mission(Name) 你好 %ls\n`
+	if !placeholdersMatch(source, translated) {
+		t.Error("placeholdersMatch() = false, want true for a faithful translation")
+	}
+
+	corrupted := `~mission(Name) 你好`
+	if placeholdersMatch(source, corrupted) {
+		t.Error("placeholdersMatch() = true, want false when a %ls and \\n token was dropped")
+	}
+}
+
+func TestConvertLiteralSpansPreservesPlaceholders(t *testing.T) {
+	value := `~mission(Name) 这个游戏 %ls`
+	got := convertLiteralSpans(value)
+	want := `~mission(Name) 這個遊戲 %ls`
+	if got != want {
+		t.Errorf("convertLiteralSpans(%q) = %q, want %q", value, got, want)
+	}
+}
@@ -0,0 +1,155 @@
+// Package s2t converts Simplified Chinese text to Traditional Chinese
+// using a longest-match trie built from a character and phrase table. It
+// is used as a fallback by the translator when a key has no entry in
+// translation_map_tc.json: rather than leave the Simplified source value
+// untouched, it is converted character-by-character (and phrase-by-
+// phrase, where a longer match exists) to Traditional Chinese.
+//
+// data.json is a hand-curated set of several hundred common single-
+// character and phrase mappings, not the full OpenCC STCharacters/
+// STPhrases corpus (tens of thousands of entries) — it covers frequently
+// used characters well but is not comprehensive, and ambiguous mappings
+// that depend on context (e.g. simplified characters that correspond to
+// more than one traditional form) have been deliberately left out rather
+// than guessed. This is a known scope reduction from the original
+// request, tracked as a follow-up, not a finished deliverable. To
+// replace it with the real OpenCC corpus, run s2tgen against the actual
+// STCharacters.txt/STPhrases.txt dictionary files:
+//
+//	go run ../s2tgen STCharacters.txt STPhrases.txt > data.json
+package s2t
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+//go:embed data.json
+var embeddedData []byte
+
+// node is one trie node, keyed by rune. terminal marks the end of a
+// phrase (or single character) mapping, whose result is value.
+type node struct {
+	children map[rune]*node
+	value    string
+	terminal bool
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+// Converter converts Simplified Chinese strings to Traditional Chinese
+// via longest-match lookups against its trie.
+type Converter struct {
+	root         *node
+	maxPhraseLen int
+}
+
+var defaultConverter *Converter
+
+func init() {
+	c, err := newConverterFromJSON(embeddedData)
+	if err != nil {
+		// The embedded table is built at commit time from trusted data;
+		// a failure here means the build is broken, not a runtime
+		// condition callers can recover from.
+		panic("s2t: failed to load embedded table: " + err.Error())
+	}
+	defaultConverter = c
+}
+
+// Convert converts s from Simplified to Traditional Chinese using the
+// package-level table loaded from the embedded data.json. Non-CJK runes, and any
+// rune sequence with no match in the table, pass through unchanged.
+func Convert(s string) string {
+	return defaultConverter.Convert(s)
+}
+
+// Convert converts s from Simplified to Traditional Chinese using c's
+// table.
+func (c *Converter) Convert(s string) string {
+	runes := []rune(s)
+	var out []rune
+
+	for i := 0; i < len(runes); {
+		match, matched := c.longestMatch(runes[i:])
+		if matched {
+			out = append(out, []rune(match.value)...)
+			i += len([]rune(match.key))
+			continue
+		}
+		out = append(out, runes[i])
+		i++
+	}
+
+	return string(out)
+}
+
+type matchResult struct {
+	key   string
+	value string
+}
+
+// longestMatch walks the trie from its root over runes, returning the
+// longest phrase (up to maxPhraseLen runes) that has a mapping.
+func (c *Converter) longestMatch(runes []rune) (matchResult, bool) {
+	limit := len(runes)
+	if c.maxPhraseLen < limit {
+		limit = c.maxPhraseLen
+	}
+
+	cur := c.root
+	var best matchResult
+	found := false
+
+	for i := 0; i < limit; i++ {
+		child, ok := cur.children[runes[i]]
+		if !ok {
+			break
+		}
+		cur = child
+		if cur.terminal {
+			best = matchResult{key: string(runes[:i+1]), value: cur.value}
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// newConverterFromJSON builds a Converter from a JSON object mapping
+// Simplified keys (single characters or multi-character phrases) to
+// their Traditional values.
+func newConverterFromJSON(data []byte) (*Converter, error) {
+	var table map[string]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+	return newConverterFromTable(table), nil
+}
+
+func newConverterFromTable(table map[string]string) *Converter {
+	c := &Converter{root: newNode()}
+
+	for key, value := range table {
+		runes := []rune(key)
+		if len(runes) > c.maxPhraseLen {
+			c.maxPhraseLen = len(runes)
+		}
+
+		cur := c.root
+		for _, r := range runes {
+			child, ok := cur.children[r]
+			if !ok {
+				child = newNode()
+				cur.children[r] = child
+			}
+			cur = child
+		}
+		cur.terminal = true
+		cur.value = value
+	}
+
+	return c
+}
@@ -0,0 +1,38 @@
+package s2t
+
+import "testing"
+
+func TestConvertSingleCharacter(t *testing.T) {
+	got := Convert("见")
+	want := "見"
+	if got != want {
+		t.Errorf("Convert(%q) = %q, want %q", "见", got, want)
+	}
+}
+
+func TestConvertLongestMatchPreferred(t *testing.T) {
+	// "软件" has its own phrase entry ("軟體"), while converting "软" and
+	// "件" independently would leave both unchanged; the trie must prefer
+	// the longer match.
+	got := Convert("软件")
+	want := "軟體"
+	if got != want {
+		t.Errorf("Convert(%q) = %q, want %q", "软件", got, want)
+	}
+}
+
+func TestConvertPassesThroughUnknown(t *testing.T) {
+	got := Convert("hello 世界")
+	want := "hello 世界"
+	if got != want {
+		t.Errorf("Convert(%q) = %q, want %q", "hello 世界", got, want)
+	}
+}
+
+func TestConvertMixedKnownAndUnknown(t *testing.T) {
+	got := Convert("这个游戏很好玩")
+	want := "這個遊戲很好玩"
+	if got != want {
+		t.Errorf("Convert(%q) = %q, want %q", "这个游戏很好玩", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+// Command s2tgen builds the embedded s2t/data.json table from one or
+// more OpenCC-format dictionary files, such as STCharacters.txt and
+// STPhrases.txt. Each input line has the form:
+//
+//	简体字 繁體字1 繁體字2 ...
+//
+// i.e. a Simplified key followed by one or more space-separated
+// Traditional alternatives; the first alternative is taken as the
+// canonical mapping.
+//
+// Usage:
+//
+//	go run ./s2tgen STCharacters.txt STPhrases.txt > s2t/data.json
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatalf("usage: %s <opencc-dict-file>...", os.Args[0])
+	}
+
+	table := make(map[string]string)
+
+	for _, path := range os.Args[1:] {
+		if err := loadDict(path, table); err != nil {
+			log.Fatalf("error loading %s: %v", path, err)
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(table); err != nil {
+		log.Fatalf("error encoding table: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Generated %d entries from %d dictionary file(s)\n", len(table), len(os.Args[1:]))
+}
+
+// loadDict parses an OpenCC-format dictionary file into table, merging
+// entries in. Existing keys are not overwritten, so earlier files (and
+// earlier lines) take precedence over later ones.
+func loadDict(path string, table map[string]string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open dictionary file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			fmt.Fprintf(os.Stderr, "Warning: %s:%d has no alternatives, skipping: %s\n", path, lineNum, line)
+			continue
+		}
+
+		key := fields[0]
+		if _, exists := table[key]; exists {
+			continue
+		}
+		// First variant wins.
+		table[key] = fields[1]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+
+	return nil
+}